@@ -0,0 +1,25 @@
+package config
+
+import (
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+
+	"github.com/spf13/cobra"
+)
+
+// NewConfigCmd creates a new cobra command for the sub command "config"
+func NewConfigCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Modify the devspace configuration",
+		Long: `
+#######################################################
+################## devspace config ####################
+#######################################################
+	`,
+	}
+
+	configCmd.AddCommand(newVarsCmd(f, globalFlags))
+
+	return configCmd
+}
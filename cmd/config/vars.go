@@ -0,0 +1,235 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/configutil"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/message"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// defaultConfigPath is used when --config isn't set, matching the config loader's own default
+const defaultConfigPath = "devspace.yaml"
+
+// varsCmd holds the cmd flags
+type varsCmd struct {
+	*flags.GlobalFlags
+
+	Output string
+}
+
+// newVarsCmd creates a new command
+func newVarsCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &varsCmd{GlobalFlags: globalFlags}
+
+	varsCmd := &cobra.Command{
+		Use:   "vars",
+		Short: "Shows the resolved predefined and user variables",
+		Long: `
+#######################################################
+############### devspace config vars ##################
+#######################################################
+Shows every predefined variable, its resolved value (or
+why it is unset), where it came from and whether it is
+actually referenced by the loaded devspace.yaml
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.RunVars(f, cobraCmd, args)
+		},
+	}
+
+	varsCmd.Flags().StringVar(&cmd.Output, "output", "table", "The output format to use. One of: table, json, yaml")
+
+	return varsCmd
+}
+
+// varInfo is the resolved state of a single predefined variable, ready for rendering
+type varInfo struct {
+	Name         string `json:"name" yaml:"name"`
+	Value        string `json:"value,omitempty" yaml:"value,omitempty"`
+	Unset        bool   `json:"unset" yaml:"unset"`
+	ErrorMessage string `json:"errorMessage,omitempty" yaml:"errorMessage,omitempty"`
+	Source       string `json:"source" yaml:"source"`
+	Referenced   bool   `json:"referenced" yaml:"referenced"`
+}
+
+// RunVars executes the functionality "devspace config vars"
+func (cmd *varsCmd) RunVars(f factory.Factory, cobraCmd *cobra.Command, args []string) error {
+	if cmd.Output != "table" && cmd.Output != "json" && cmd.Output != "yaml" {
+		return errors.Errorf("unsupported --output %s: must be one of table, json, yaml", cmd.Output)
+	}
+
+	// Set config root
+	log := f.GetLog()
+	configOptions := cmd.ToConfigOptions(log)
+	configLoader := f.NewConfigLoader(cmd.ConfigPath)
+	configExists, err := configLoader.SetDevSpaceRoot(log)
+	if err != nil {
+		return err
+	}
+	if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	// Make dependencies' local checkout paths available as predefined vars
+	// before the config is loaded, so the parent devspace.yaml's own ${VAR}
+	// substitution can reference them
+	if err := dependency.RegisterVars(cmd.ConfigPath); err != nil {
+		return err
+	}
+
+	// Get the config the same way `devspace update dependencies` does. This
+	// also validates the devspace.yaml is otherwise loadable before we
+	// report on its variables.
+	if _, err := configLoader.Load(configOptions, log); err != nil {
+		return err
+	}
+
+	if err := configutil.FillPredefinedVars(cmd.KubeContext); err != nil {
+		return err
+	}
+
+	// Referenced is checked against the raw, unresolved devspace.yaml rather
+	// than the loaded config, because by the time Load() returns, every
+	// ${VAR} has already been substituted with its resolved value and no
+	// longer appears as literal "${VAR}" text.
+	configPath := cmd.ConfigPath
+	if configPath == "" {
+		configPath = defaultConfigPath
+	}
+
+	rawConfig, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return errors.Wrap(err, "read "+configPath)
+	}
+
+	infos := make([]varInfo, 0)
+	for _, predefined := range configutil.ListPredefinedVars() {
+		source := "predefined"
+		if _, ok := os.LookupEnv("DEVSPACE_VAR_" + strings.ToUpper(predefined.Name)); ok {
+			source = "env override"
+		}
+
+		infos = append(infos, varInfo{
+			Name:         predefined.Name,
+			Value:        predefined.Value,
+			Unset:        predefined.Unset,
+			ErrorMessage: predefined.ErrorMessage,
+			Source:       source,
+			Referenced:   isReferenced(rawConfig, predefined.Name),
+		})
+	}
+
+	userVars, err := parseUserVars(rawConfig)
+	if err != nil {
+		return errors.Wrap(err, "parse vars from "+configPath)
+	}
+
+	for _, userVar := range userVars {
+		source := "vars: (devspace.yaml)"
+		if _, ok := os.LookupEnv("DEVSPACE_VAR_" + userVar.Name); ok {
+			source = "env override"
+		}
+
+		infos = append(infos, varInfo{
+			Name:       userVar.Name,
+			Value:      userVar.Value,
+			Unset:      userVar.Value == "",
+			Source:     source,
+			Referenced: isReferenced(rawConfig, userVar.Name),
+		})
+	}
+
+	switch cmd.Output {
+	case "json":
+		out, err := json.MarshalIndent(infos, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(out))
+	case "yaml":
+		out, err := yaml.Marshal(infos)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(out))
+	default:
+		printVarsTable(infos)
+	}
+
+	return nil
+}
+
+// userVar is a single entry of the `vars:` section of devspace.yaml
+type userVar struct {
+	Name  string
+	Value string
+}
+
+// parseUserVars extracts the `vars:` section from the raw devspace.yaml so
+// user-defined variables can be listed alongside the predefined ones. A
+// var's default `value:` (if any) is shown; vars without a default are
+// reported as unset, same as an un-filled predefined var, since resolving
+// them further would require the interactive prompt the config loader runs
+func parseUserVars(rawConfig []byte) ([]userVar, error) {
+	parsed := struct {
+		Vars []struct {
+			Name  string `yaml:"name"`
+			Value string `yaml:"value"`
+		} `yaml:"vars"`
+	}{}
+
+	if err := yaml.Unmarshal(rawConfig, &parsed); err != nil {
+		return nil, err
+	}
+
+	userVars := make([]userVar, 0, len(parsed.Vars))
+	for _, v := range parsed.Vars {
+		userVars = append(userVars, userVar{Name: v.Name, Value: v.Value})
+	}
+
+	return userVars, nil
+}
+
+// isReferenced checks whether name is referenced in rawConfig as either
+// ${NAME} or a bare $NAME not immediately followed by another identifier
+// character (so $DEVSPACE_RANDOM doesn't also match $DEVSPACE_RANDOM_SUFFIX)
+func isReferenced(rawConfig []byte, name string) bool {
+	pattern := regexp.MustCompile(`\$\{` + regexp.QuoteMeta(name) + `\}|\$` + regexp.QuoteMeta(name) + `\b`)
+	return pattern.Match(rawConfig)
+}
+
+func printVarsTable(infos []varInfo) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tVALUE\tSOURCE\tREFERENCED")
+
+	for _, info := range infos {
+		value := info.Value
+		if info.Unset {
+			value = "<unset>"
+			if info.ErrorMessage != "" {
+				value = fmt.Sprintf("<unset>: %s", info.ErrorMessage)
+			}
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%v\n", info.Name, value, info.Source, info.Referenced)
+	}
+
+	writer.Flush()
+}
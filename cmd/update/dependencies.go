@@ -13,6 +13,12 @@ import (
 // dependenciesCmd holds the cmd flags
 type dependenciesCmd struct {
 	*flags.GlobalFlags
+
+	Dependency []string
+	Parallel   int
+	Branch     string
+	SkipPush   bool
+	DryRun     bool
 }
 
 // newDependenciesCmd creates a new command
@@ -36,6 +42,12 @@ in the devspace.yaml
 		},
 	}
 
+	dependenciesCmd.Flags().StringArrayVar(&cmd.Dependency, "dependency", nil, "Only update the dependency with this name or source path/repo (can be specified multiple times). Updates all dependencies if not set")
+	dependenciesCmd.Flags().IntVar(&cmd.Parallel, "parallel", 1, "Max number of dependencies to update concurrently")
+	dependenciesCmd.Flags().StringVar(&cmd.Branch, "branch", "", "Overrides the branch that is checked out for the selected dependencies")
+	dependenciesCmd.Flags().BoolVar(&cmd.SkipPush, "skip-push", false, "Skips pushing the dependency repositories (no-op, kept for flag parity with devspace deploy)")
+	dependenciesCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "Shows which dependencies would be updated without actually updating them")
+
 	return dependenciesCmd
 }
 
@@ -53,6 +65,13 @@ func (cmd *dependenciesCmd) RunDependencies(f factory.Factory, cobraCmd *cobra.C
 		return errors.New(message.ConfigNotFound)
 	}
 
+	// Make dependencies' local checkout paths available as predefined vars
+	// before the config is loaded, so the parent devspace.yaml's own ${VAR}
+	// substitution can reference them
+	if err := dependency.RegisterVars(cmd.ConfigPath); err != nil {
+		return err
+	}
+
 	// Get the config
 	config, err := configLoader.Load(configOptions, log)
 	if err != nil {
@@ -60,11 +79,24 @@ func (cmd *dependenciesCmd) RunDependencies(f factory.Factory, cobraCmd *cobra.C
 	}
 
 	// Update dependencies
-	err = dependency.NewManager(config, nil, configOptions, log).UpdateAll()
+	matched, err := dependency.NewManager(config, nil, configOptions, log).UpdateSelected(cmd.Dependency, dependency.UpdateOptions{
+		Branch:   cmd.Branch,
+		Parallel: cmd.Parallel,
+		SkipPush: cmd.SkipPush,
+		DryRun:   cmd.DryRun,
+	})
 	if err != nil {
 		return err
 	}
 
-	log.Donef("Successfully updated all dependencies")
+	switch {
+	case cmd.DryRun:
+		log.Donef("Dry run finished, no dependencies were updated")
+	case len(cmd.Dependency) > 0:
+		log.Donef("Successfully updated %d selected dependencies", matched)
+	default:
+		log.Donef("Successfully updated all dependencies")
+	}
+
 	return nil
 }
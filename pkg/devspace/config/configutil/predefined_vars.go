@@ -16,186 +16,386 @@ import (
 	"github.com/pkg/errors"
 )
 
-// PredefinedVars holds all predefined variables that can be used in the config
-var PredefinedVars = map[string]*predefinedVarDefinition{
-	"DEVSPACE_RANDOM": &predefinedVarDefinition{
-		Fill: func(kubeContext string) (*string, error) {
-			ret, err := randutil.GenerateRandomString(6)
-			if err != nil {
-				return nil, err
-			}
-
-			return &ret, nil
-		},
-	},
-	"DEVSPACE_TIMESTAMP": &predefinedVarDefinition{
-		Fill: func(kubeContext string) (*string, error) {
-			return ptr.String(strconv.FormatInt(time.Now().Unix(), 10)), nil
-		},
-	},
-	"DEVSPACE_GIT_COMMIT": &predefinedVarDefinition{
-		ErrorMessage: "No git repository found, but predefined var DEVSPACE_GIT_COMMIT is used",
-		Fill: func(kubeContext string) (*string, error) {
-			gitRepo := git.NewGitRepository(".", "")
-
-			hash, err := gitRepo.GetHash()
-			if err != nil {
-				return nil, nil
-			}
-
-			return ptr.String(hash[:8]), nil
-		},
-	},
-	"DEVSPACE_SPACE": &predefinedVarDefinition{
-		ErrorMessage: fmt.Sprintf("Current context is not a space, but predefined var DEVSPACE_SPACE is used.\n\nPlease run: \n- `%s` to create a new space\n- `%s` to use an existing space\n- `%s` to list existing spaces", ansi.Color("devspace create space [NAME]", "white+b"), ansi.Color("devspace use space [NAME]", "white+b"), ansi.Color("devspace list spaces", "white+b")),
-		Fill: func(overrideKubeContext string) (*string, error) {
-			kubeContext, err := kubeconfig.GetCurrentContext()
-			if err != nil {
-				return nil, nil
-			}
-			if overrideKubeContext != "" {
-				kubeContext = overrideKubeContext
-			}
-
-			isSpace, err := kubeconfig.IsCloudSpace(kubeContext)
-			if err != nil || !isSpace {
-				return nil, nil
-			}
-
-			spaceID, providerName, err := kubeconfig.GetSpaceID(kubeContext)
-			if err != nil {
-				return nil, err
-			}
-
-			cloudConfigData, err := cloudconfig.ParseProviderConfig()
-			if err != nil {
-				return nil, nil
-			}
-
-			provider := cloudconfig.GetProvider(cloudConfigData, providerName)
-			if provider == nil {
-				return nil, nil
-			}
-			if provider.Spaces == nil {
-				return nil, nil
-			}
-			if provider.Spaces[spaceID] == nil {
-				return nil, nil
-			}
-
-			return &provider.Spaces[spaceID].Space.Name, nil
-		},
-	},
-	"DEVSPACE_SPACE_NAMESPACE": &predefinedVarDefinition{
-		ErrorMessage: fmt.Sprintf("Current context is not a space, but predefined var DEVSPACE_SPACE_NAMESPACE is used.\n\nPlease run: \n- `%s` to create a new space\n- `%s` to use an existing space\n- `%s` to list existing spaces", ansi.Color("devspace create space [NAME]", "white+b"), ansi.Color("devspace use space [NAME]", "white+b"), ansi.Color("devspace list spaces", "white+b")),
-		Fill: func(overrideKubeContext string) (*string, error) {
-			kubeContext, err := kubeconfig.GetCurrentContext()
-			if err != nil {
-				return nil, nil
-			}
-			if overrideKubeContext != "" {
-				kubeContext = overrideKubeContext
-			}
-
-			isSpace, err := kubeconfig.IsCloudSpace(kubeContext)
-			if err != nil || !isSpace {
-				return nil, nil
-			}
-
-			spaceID, providerName, err := kubeconfig.GetSpaceID(kubeContext)
-			if err != nil {
-				return nil, err
-			}
-
-			cloudConfigData, err := cloudconfig.ParseProviderConfig()
-			if err != nil {
-				return nil, nil
-			}
-
-			provider := cloudconfig.GetProvider(cloudConfigData, providerName)
-			if provider == nil {
-				return nil, nil
-			}
-			if provider.Spaces == nil {
-				return nil, nil
-			}
-			if provider.Spaces[spaceID] == nil {
-				return nil, nil
-			}
-
-			return &provider.Spaces[spaceID].ServiceAccount.Namespace, nil
-		},
-	},
-	"DEVSPACE_USERNAME": &predefinedVarDefinition{
-		ErrorMessage: fmt.Sprintf("You are not logged into DevSpace Cloud, but predefined var DEVSPACE_USERNAME is used.\n\nPlease run: \n- `%s` to login into devspace cloud. Alternatively you can also remove the variable ${DEVSPACE_USERNAME} from your config", ansi.Color("devspace login", "white+b")),
-		Fill: func(overrideKubeContext string) (*string, error) {
-			kubeContext, err := kubeconfig.GetCurrentContext()
-			if err != nil {
-				return nil, err
-			}
-			if overrideKubeContext != "" {
-				kubeContext = overrideKubeContext
-			}
-
-			cloudConfigData, err := cloudconfig.ParseProviderConfig()
-			if err != nil {
-				return nil, err
-			}
-
-			_, providerName, err := kubeconfig.GetSpaceID(kubeContext)
-			if err != nil {
-				// use global provider config as fallback
-				if cloudConfigData.Default != "" {
-					providerName = cloudConfigData.Default
-				} else {
-					providerName = cloudconfig.DevSpaceCloudProviderName
-				}
-			}
-
-			provider := cloudconfig.GetProvider(cloudConfigData, providerName)
-			if provider == nil {
-				return nil, nil
-			}
-			if provider.Token == "" {
-				return nil, nil
-			}
-
-			accountName, err := cloudtoken.GetAccountName(provider.Token)
-			if err != nil {
-				return nil, nil
-			}
-
-			return &accountName, nil
-		},
-	},
-}
-
-type predefinedVarDefinition struct {
-	Value        *string
-	ErrorMessage string
-	Fill         func(string) (*string, error)
+func init() {
+	DefaultVarRegistry.Register(&randomVar{})
+	DefaultVarRegistry.Register(&timestampVar{})
+	DefaultVarRegistry.Register(&gitCommitVar{})
+	DefaultVarRegistry.Register(&spaceVar{})
+	DefaultVarRegistry.Register(&spaceNamespaceVar{})
+	DefaultVarRegistry.Register(&usernameVar{})
+	DefaultVarRegistry.Register(&namespaceVar{})
+	DefaultVarRegistry.Register(&contextVar{})
+	DefaultVarRegistry.Register(&clusterVar{})
+	DefaultVarRegistry.Register(&userVar{})
+	DefaultVarRegistry.Register(&gitBranchVar{})
+	DefaultVarRegistry.Register(&gitTagVar{})
+	DefaultVarRegistry.Register(&gitCommitFullVar{})
+	DefaultVarRegistry.Register(&gitRemoteVar{})
+	DefaultVarRegistry.Register(&gitDirtyVar{})
+	DefaultVarRegistry.Register(&gitDescribeVar{})
 }
 
-func fillPredefinedVars(overrideKubeContext string) error {
-	for varName, predefinedVariable := range PredefinedVars {
-		val, err := predefinedVariable.Fill(overrideKubeContext)
-		if err != nil {
-			return errors.Wrap(err, "fill predefined var "+varName)
+// randomVar resolves DEVSPACE_RANDOM to a random 6 character string
+type randomVar struct{}
+
+func (randomVar) Name() string         { return "DEVSPACE_RANDOM" }
+func (randomVar) ErrorMessage() string { return "" }
+func (randomVar) Fill(kubeContext string) (*string, error) {
+	ret, err := randutil.GenerateRandomString(6)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// timestampVar resolves DEVSPACE_TIMESTAMP to the current unix timestamp
+type timestampVar struct{}
+
+func (timestampVar) Name() string         { return "DEVSPACE_TIMESTAMP" }
+func (timestampVar) ErrorMessage() string { return "" }
+func (timestampVar) Fill(kubeContext string) (*string, error) {
+	return ptr.String(strconv.FormatInt(time.Now().Unix(), 10)), nil
+}
+
+// gitCommitVar resolves DEVSPACE_GIT_COMMIT to the short hash of the current commit
+type gitCommitVar struct{}
+
+func (gitCommitVar) Name() string { return "DEVSPACE_GIT_COMMIT" }
+func (gitCommitVar) ErrorMessage() string {
+	return "No git repository found, but predefined var DEVSPACE_GIT_COMMIT is used"
+}
+func (gitCommitVar) Fill(kubeContext string) (*string, error) {
+	gitRepo := git.NewGitRepository(".", "")
+
+	hash, err := gitRepo.GetHash()
+	if err != nil {
+		return nil, nil
+	}
+
+	return ptr.String(hash[:8]), nil
+}
+
+// spaceVar resolves DEVSPACE_SPACE to the name of the current DevSpace Cloud space
+type spaceVar struct{}
+
+func (spaceVar) Name() string { return "DEVSPACE_SPACE" }
+func (spaceVar) ErrorMessage() string {
+	return fmt.Sprintf("Current context is not a space, but predefined var DEVSPACE_SPACE is used.\n\nPlease run: \n- `%s` to create a new space\n- `%s` to use an existing space\n- `%s` to list existing spaces", ansi.Color("devspace create space [NAME]", "white+b"), ansi.Color("devspace use space [NAME]", "white+b"), ansi.Color("devspace list spaces", "white+b"))
+}
+func (spaceVar) Fill(overrideKubeContext string) (*string, error) {
+	kubeContext, err := kubeconfig.GetCurrentContext()
+	if err != nil {
+		return nil, nil
+	}
+	if overrideKubeContext != "" {
+		kubeContext = overrideKubeContext
+	}
+
+	isSpace, err := kubeconfig.IsCloudSpace(kubeContext)
+	if err != nil || !isSpace {
+		return nil, nil
+	}
+
+	spaceID, providerName, err := kubeconfig.GetSpaceID(kubeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudConfigData, err := cloudconfig.ParseProviderConfig()
+	if err != nil {
+		return nil, nil
+	}
+
+	provider := cloudconfig.GetProvider(cloudConfigData, providerName)
+	if provider == nil {
+		return nil, nil
+	}
+	if provider.Spaces == nil {
+		return nil, nil
+	}
+	if provider.Spaces[spaceID] == nil {
+		return nil, nil
+	}
+
+	return &provider.Spaces[spaceID].Space.Name, nil
+}
+
+// spaceNamespaceVar resolves DEVSPACE_SPACE_NAMESPACE to the namespace of the current DevSpace Cloud space
+type spaceNamespaceVar struct{}
+
+func (spaceNamespaceVar) Name() string { return "DEVSPACE_SPACE_NAMESPACE" }
+func (spaceNamespaceVar) ErrorMessage() string {
+	return fmt.Sprintf("Current context is not a space, but predefined var DEVSPACE_SPACE_NAMESPACE is used.\n\nPlease run: \n- `%s` to create a new space\n- `%s` to use an existing space\n- `%s` to list existing spaces", ansi.Color("devspace create space [NAME]", "white+b"), ansi.Color("devspace use space [NAME]", "white+b"), ansi.Color("devspace list spaces", "white+b"))
+}
+func (spaceNamespaceVar) Fill(overrideKubeContext string) (*string, error) {
+	kubeContext, err := kubeconfig.GetCurrentContext()
+	if err != nil {
+		return nil, nil
+	}
+	if overrideKubeContext != "" {
+		kubeContext = overrideKubeContext
+	}
+
+	isSpace, err := kubeconfig.IsCloudSpace(kubeContext)
+	if err != nil || !isSpace {
+		return nil, nil
+	}
+
+	spaceID, providerName, err := kubeconfig.GetSpaceID(kubeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudConfigData, err := cloudconfig.ParseProviderConfig()
+	if err != nil {
+		return nil, nil
+	}
+
+	provider := cloudconfig.GetProvider(cloudConfigData, providerName)
+	if provider == nil {
+		return nil, nil
+	}
+	if provider.Spaces == nil {
+		return nil, nil
+	}
+	if provider.Spaces[spaceID] == nil {
+		return nil, nil
+	}
+
+	return &provider.Spaces[spaceID].ServiceAccount.Namespace, nil
+}
+
+// usernameVar resolves DEVSPACE_USERNAME to the account name of the logged in user
+type usernameVar struct{}
+
+func (usernameVar) Name() string { return "DEVSPACE_USERNAME" }
+func (usernameVar) ErrorMessage() string {
+	return fmt.Sprintf("You are not logged into DevSpace Cloud, but predefined var DEVSPACE_USERNAME is used.\n\nPlease run: \n- `%s` to login into devspace cloud. Alternatively you can also remove the variable ${DEVSPACE_USERNAME} from your config", ansi.Color("devspace login", "white+b"))
+}
+func (usernameVar) Fill(overrideKubeContext string) (*string, error) {
+	kubeContext, err := kubeconfig.GetCurrentContext()
+	if err != nil {
+		return nil, err
+	}
+	if overrideKubeContext != "" {
+		kubeContext = overrideKubeContext
+	}
+
+	cloudConfigData, err := cloudconfig.ParseProviderConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	_, providerName, err := kubeconfig.GetSpaceID(kubeContext)
+	if err != nil {
+		// use global provider config as fallback
+		if cloudConfigData.Default != "" {
+			providerName = cloudConfigData.Default
+		} else {
+			providerName = cloudconfig.DevSpaceCloudProviderName
 		}
+	}
+
+	provider := cloudconfig.GetProvider(cloudConfigData, providerName)
+	if provider == nil {
+		return nil, nil
+	}
+	if provider.Token == "" {
+		return nil, nil
+	}
 
-		predefinedVariable.Value = val
+	accountName, err := cloudtoken.GetAccountName(provider.Token)
+	if err != nil {
+		return nil, nil
 	}
 
-	return nil
+	return &accountName, nil
+}
+
+// gitBranchVar resolves DEVSPACE_GIT_BRANCH to the current branch, or "HEAD"
+// if the repository is in detached HEAD state
+type gitBranchVar struct{}
+
+func (gitBranchVar) Name() string { return "DEVSPACE_GIT_BRANCH" }
+func (gitBranchVar) ErrorMessage() string {
+	return "No git repository found, but predefined var DEVSPACE_GIT_BRANCH is used"
+}
+func (gitBranchVar) Fill(kubeContext string) (*string, error) {
+	branch, err := git.NewGitRepository(".", "").GetBranch()
+	if err != nil {
+		return nil, nil
+	}
+
+	return &branch, nil
+}
+
+// gitTagVar resolves DEVSPACE_GIT_TAG to the nearest annotated tag, or an
+// empty string when there is none
+type gitTagVar struct{}
+
+func (gitTagVar) Name() string { return "DEVSPACE_GIT_TAG" }
+func (gitTagVar) ErrorMessage() string {
+	return "No git repository found, but predefined var DEVSPACE_GIT_TAG is used"
+}
+func (gitTagVar) Fill(kubeContext string) (*string, error) {
+	tag, err := git.NewGitRepository(".", "").GetTag()
+	if err != nil {
+		return nil, nil
+	}
+
+	return &tag, nil
+}
+
+// gitCommitFullVar resolves DEVSPACE_GIT_COMMIT_FULL to the full 40-char commit hash
+type gitCommitFullVar struct{}
+
+func (gitCommitFullVar) Name() string { return "DEVSPACE_GIT_COMMIT_FULL" }
+func (gitCommitFullVar) ErrorMessage() string {
+	return "No git repository found, but predefined var DEVSPACE_GIT_COMMIT_FULL is used"
+}
+func (gitCommitFullVar) Fill(kubeContext string) (*string, error) {
+	hash, err := git.NewGitRepository(".", "").GetHash()
+	if err != nil {
+		return nil, nil
+	}
+
+	return &hash, nil
+}
+
+// gitRemoteVar resolves DEVSPACE_GIT_REMOTE to the URL of the "origin"
+// remote, or of the first configured remote
+type gitRemoteVar struct{}
+
+func (gitRemoteVar) Name() string { return "DEVSPACE_GIT_REMOTE" }
+func (gitRemoteVar) ErrorMessage() string {
+	return "No git repository found, but predefined var DEVSPACE_GIT_REMOTE is used"
+}
+func (gitRemoteVar) Fill(kubeContext string) (*string, error) {
+	remote, err := git.NewGitRepository(".", "").GetRemote()
+	if err != nil {
+		return nil, nil
+	}
+
+	return &remote, nil
+}
+
+// gitDirtyVar resolves DEVSPACE_GIT_DIRTY to "true"/"false" depending on
+// whether the working tree has uncommitted changes
+type gitDirtyVar struct{}
+
+func (gitDirtyVar) Name() string { return "DEVSPACE_GIT_DIRTY" }
+func (gitDirtyVar) ErrorMessage() string {
+	return "No git repository found, but predefined var DEVSPACE_GIT_DIRTY is used"
+}
+func (gitDirtyVar) Fill(kubeContext string) (*string, error) {
+	dirty, err := git.NewGitRepository(".", "").IsDirty()
+	if err != nil {
+		return nil, nil
+	}
+
+	return ptr.String(strconv.FormatBool(dirty)), nil
+}
+
+// gitDescribeVar resolves DEVSPACE_GIT_DESCRIBE to the output of
+// `git describe --tags --always --dirty`
+type gitDescribeVar struct{}
+
+func (gitDescribeVar) Name() string { return "DEVSPACE_GIT_DESCRIBE" }
+func (gitDescribeVar) ErrorMessage() string {
+	return "No git repository found, but predefined var DEVSPACE_GIT_DESCRIBE is used"
+}
+func (gitDescribeVar) Fill(kubeContext string) (*string, error) {
+	describe, err := git.NewGitRepository(".", "").Describe()
+	if err != nil {
+		return nil, nil
+	}
+
+	return &describe, nil
+}
+
+// namespaceVar resolves DEVSPACE_NAMESPACE to the namespace of the effective
+// kube context (the one the build actually targets), falling back to
+// "default". Unlike DEVSPACE_SPACE_NAMESPACE this works against any
+// kubeconfig, not just DevSpace Cloud spaces.
+type namespaceVar struct{}
+
+func (namespaceVar) Name() string { return "DEVSPACE_NAMESPACE" }
+func (namespaceVar) ErrorMessage() string {
+	return "No active kube context found, but predefined var DEVSPACE_NAMESPACE is used"
+}
+func (namespaceVar) Fill(overrideKubeContext string) (*string, error) {
+	namespace, err := kubeconfig.GetCurrentNamespace(overrideKubeContext)
+	if err != nil {
+		return nil, nil
+	}
+
+	return &namespace, nil
+}
+
+// contextVar resolves DEVSPACE_CONTEXT to the name of the effective kube context
+type contextVar struct{}
+
+func (contextVar) Name() string { return "DEVSPACE_CONTEXT" }
+func (contextVar) ErrorMessage() string {
+	return "No active kube context found, but predefined var DEVSPACE_CONTEXT is used"
+}
+func (contextVar) Fill(overrideKubeContext string) (*string, error) {
+	kubeContext, err := kubeconfig.GetCurrentContext()
+	if err != nil {
+		return nil, nil
+	}
+	if overrideKubeContext != "" {
+		kubeContext = overrideKubeContext
+	}
+
+	return &kubeContext, nil
+}
+
+// clusterVar resolves DEVSPACE_CLUSTER to the cluster of the effective kube context
+type clusterVar struct{}
+
+func (clusterVar) Name() string { return "DEVSPACE_CLUSTER" }
+func (clusterVar) ErrorMessage() string {
+	return "No active kube context found, but predefined var DEVSPACE_CLUSTER is used"
+}
+func (clusterVar) Fill(overrideKubeContext string) (*string, error) {
+	cluster, err := kubeconfig.GetCurrentClusterName(overrideKubeContext)
+	if err != nil || cluster == "" {
+		return nil, nil
+	}
+
+	return &cluster, nil
+}
+
+// userVar resolves DEVSPACE_USER to the auth-info name of the effective kube context
+type userVar struct{}
+
+func (userVar) Name() string { return "DEVSPACE_USER" }
+func (userVar) ErrorMessage() string {
+	return "No active kube context found, but predefined var DEVSPACE_USER is used"
+}
+func (userVar) Fill(overrideKubeContext string) (*string, error) {
+	user, err := kubeconfig.GetCurrentAuthInfoName(overrideKubeContext)
+	if err != nil || user == "" {
+		return nil, nil
+	}
+
+	return &user, nil
+}
+
+func fillPredefinedVars(overrideKubeContext string) error {
+	return DefaultVarRegistry.fillAll(overrideKubeContext)
 }
 
 func getPredefinedVar(name, overrideKubeContext string) (bool, string, error) {
-	if variable, ok := PredefinedVars[strings.ToUpper(name)]; ok {
-		if variable.Value == nil {
-			return false, "", errors.New(variable.ErrorMessage)
+	if filler, ok := DefaultVarRegistry.Lookup(name); ok {
+		val, ok := DefaultVarRegistry.value(filler.Name())
+		if !ok || val == nil {
+			return false, "", errors.New(filler.ErrorMessage())
 		}
 
-		return true, *variable.Value, nil
+		return true, *val, nil
 	}
 
 	// Load space domain environment variable
@@ -242,4 +442,4 @@ func getPredefinedVar(name, overrideKubeContext string) (bool, string, error) {
 	}
 
 	return false, "", nil
-}
\ No newline at end of file
+}
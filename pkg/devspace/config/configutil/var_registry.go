@@ -0,0 +1,197 @@
+package configutil
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// envOverridePrefix is the environment variable prefix used to force a
+// predefined variable's value without running its Filler, e.g.
+// `DEVSPACE_VAR_DEVSPACE_GIT_COMMIT=abc123` overrides DEVSPACE_GIT_COMMIT.
+// This is mainly useful on CI, where a shallow clone or an archived
+// checkout may not have a `.git` directory, or where there is no
+// kubeconfig/space to resolve a var from.
+//
+// Resolution precedence is, highest first:
+//  1. DEVSPACE_VAR_<NAME> environment variable (this layer; skips Fill and ErrorMessage entirely)
+//  2. the registered Filler's Fill function
+//  3. a user-defined `vars:` entry in devspace.yaml, which prompts if still unset
+const envOverridePrefix = "DEVSPACE_VAR_"
+
+// lookupEnvOverride returns the DEVSPACE_VAR_<NAME> environment variable for
+// name, if set
+func lookupEnvOverride(name string) (string, bool) {
+	return os.LookupEnv(envOverridePrefix + strings.ToUpper(name))
+}
+
+// Filler is implemented by anything that can resolve the value of a
+// predefined variable. It is the extension point third-party code (custom
+// cloud providers, CI systems, secret backends, ...) uses to contribute
+// additional predefined variables without having to patch this package,
+// following the same pattern as pluggable admission/authorization plugins
+// in Kubernetes.
+type Filler interface {
+	// Name returns the name of the predefined variable, e.g. "DEVSPACE_GIT_COMMIT"
+	Name() string
+
+	// Fill resolves the variable's value for the given (possibly overridden)
+	// kube context. A nil *string means the variable is currently unset
+	// (e.g. not running against a space) and ErrorMessage explains why if
+	// the variable is referenced anyway.
+	Fill(kubeContext string) (*string, error)
+
+	// ErrorMessage is shown to the user if the variable is referenced in the
+	// config but Fill returned a nil value.
+	ErrorMessage() string
+}
+
+// VarRegistry keeps track of all registered predefined variable fillers and
+// their last resolved values.
+type VarRegistry struct {
+	mutex   sync.RWMutex
+	fillers map[string]Filler
+	values  map[string]*string
+}
+
+// newVarRegistry creates an empty registry
+func newVarRegistry() *VarRegistry {
+	return &VarRegistry{
+		fillers: map[string]Filler{},
+		values:  map[string]*string{},
+	}
+}
+
+// Register adds a filler to the registry. Registering a filler under a name
+// that is already taken replaces the previous one, so dependencies are able
+// to override built-in vars if they need to.
+func (r *VarRegistry) Register(filler Filler) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.fillers[strings.ToUpper(filler.Name())] = filler
+}
+
+// Lookup returns the filler registered under name, if any
+func (r *VarRegistry) Lookup(name string) (Filler, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	filler, ok := r.fillers[strings.ToUpper(name)]
+	return filler, ok
+}
+
+// names returns the names of all registered fillers
+func (r *VarRegistry) names() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	names := make([]string, 0, len(r.fillers))
+	for name := range r.fillers {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// fillAll resolves every registered filler for the given kube context. A
+// DEVSPACE_VAR_<NAME> environment variable takes precedence over the
+// filler: when set, its string value is used verbatim and the filler's Fill
+// (and ErrorMessage) is skipped entirely for that name.
+func (r *VarRegistry) fillAll(overrideKubeContext string) error {
+	for _, name := range r.names() {
+		filler, ok := r.Lookup(name)
+		if !ok {
+			continue
+		}
+
+		if envValue, ok := lookupEnvOverride(name); ok {
+			r.mutex.Lock()
+			r.values[name] = &envValue
+			r.mutex.Unlock()
+			continue
+		}
+
+		val, err := filler.Fill(overrideKubeContext)
+		if err != nil {
+			return errors.Wrap(err, "fill predefined var "+name)
+		}
+
+		r.mutex.Lock()
+		r.values[name] = val
+		r.mutex.Unlock()
+	}
+
+	return nil
+}
+
+// value returns the last resolved value for name, if the filler has run
+func (r *VarRegistry) value(name string) (*string, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	val, ok := r.values[strings.ToUpper(name)]
+	return val, ok
+}
+
+// DefaultVarRegistry is the registry the config loader uses to resolve
+// predefined variables. cmd/* code (e.g. dependency.RegisterVars, which
+// cmd/update/dependencies.go calls for the configured dependencies) can call
+// RegisterVar before the config is loaded to contribute additional fillers.
+var DefaultVarRegistry = newVarRegistry()
+
+// RegisterVar registers an additional predefined variable filler on the
+// default registry. It is exported so that code outside this package can
+// inject custom fillers -- for a custom cloud provider, CI system, or
+// secret backend -- before the config is loaded.
+func RegisterVar(filler Filler) {
+	DefaultVarRegistry.Register(filler)
+}
+
+// FillPredefinedVars resolves every registered predefined variable for the
+// given kube context. It is exported so introspection commands (e.g.
+// `devspace config vars`) can trigger resolution without loading a config.
+func FillPredefinedVars(overrideKubeContext string) error {
+	return fillPredefinedVars(overrideKubeContext)
+}
+
+// PredefinedVarInfo describes the resolved state of a single predefined variable
+type PredefinedVarInfo struct {
+	Name         string
+	Value        string
+	Unset        bool
+	ErrorMessage string
+}
+
+// ListPredefinedVars returns the resolved state of every registered
+// predefined variable, sorted by name, for introspection/debugging (e.g.
+// `devspace config vars`). FillPredefinedVars should be called first.
+func ListPredefinedVars() []PredefinedVarInfo {
+	names := DefaultVarRegistry.names()
+	sort.Strings(names)
+
+	infos := make([]PredefinedVarInfo, 0, len(names))
+	for _, name := range names {
+		filler, ok := DefaultVarRegistry.Lookup(name)
+		if !ok {
+			continue
+		}
+
+		info := PredefinedVarInfo{Name: filler.Name()}
+
+		val, ok := DefaultVarRegistry.value(name)
+		if !ok || val == nil {
+			info.Unset = true
+			info.ErrorMessage = filler.ErrorMessage()
+		} else {
+			info.Value = *val
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos
+}
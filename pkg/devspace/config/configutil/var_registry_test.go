@@ -0,0 +1,153 @@
+package configutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+// fillerFunc adapts a plain function to the Filler interface for tests
+type fillerFunc struct {
+	name         string
+	errorMessage string
+	fill         func(kubeContext string) (*string, error)
+}
+
+func (f *fillerFunc) Name() string         { return f.name }
+func (f *fillerFunc) ErrorMessage() string { return f.errorMessage }
+func (f *fillerFunc) Fill(kubeContext string) (*string, error) {
+	return f.fill(kubeContext)
+}
+
+// unregister removes name from r, restoring it to a clean state. Used by
+// tests that need to register throwaway fillers on the shared
+// DefaultVarRegistry without leaking them into other tests.
+func (r *VarRegistry) unregister(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.fillers, strings.ToUpper(name))
+	delete(r.values, strings.ToUpper(name))
+}
+
+func TestEnvOverrideSkipsFiller(t *testing.T) {
+	registry := newVarRegistry()
+	called := false
+	registry.Register(&fillerFunc{
+		name:         "TEST_GIT_COMMIT",
+		errorMessage: "No git repository found",
+		fill: func(kubeContext string) (*string, error) {
+			called = true
+			return nil, nil
+		},
+	})
+
+	os.Setenv(envOverridePrefix+"TEST_GIT_COMMIT", "shallow-clone-override")
+	defer os.Unsetenv(envOverridePrefix + "TEST_GIT_COMMIT")
+
+	err := registry.fillAll("")
+	assert.NilError(t, err)
+	assert.Equal(t, called, false, "env override should skip the filler")
+
+	val, ok := registry.value("TEST_GIT_COMMIT")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, *val, "shallow-clone-override")
+}
+
+func TestNoEnvOverrideUsesFiller(t *testing.T) {
+	registry := newVarRegistry()
+	registry.Register(&fillerFunc{
+		name:         "TEST_NAMESPACE",
+		errorMessage: "No active kube context found",
+		fill: func(kubeContext string) (*string, error) {
+			// simulates running with no kubeconfig present
+			return nil, nil
+		},
+	})
+
+	err := registry.fillAll("")
+	assert.NilError(t, err)
+
+	val, ok := registry.value("TEST_NAMESPACE")
+	assert.Equal(t, ok, true)
+	assert.Assert(t, val == nil)
+}
+
+func TestGetPredefinedVarReturnsErrorMessageWhenUnsetWithoutOverride(t *testing.T) {
+	name := "DEVSPACE_VAR_TEST_UNSET"
+	DefaultVarRegistry.Register(&fillerFunc{
+		name:         name,
+		errorMessage: "No git repository found, but predefined var is used",
+		fill: func(kubeContext string) (*string, error) {
+			return nil, nil
+		},
+	})
+	t.Cleanup(func() { DefaultVarRegistry.unregister(name) })
+
+	err := fillPredefinedVars("")
+	assert.NilError(t, err)
+
+	_, _, err = getPredefinedVar(name, "")
+	assert.ErrorContains(t, err, "No git repository found")
+}
+
+func TestGetPredefinedVarHonorsEnvOverride(t *testing.T) {
+	name := "DEVSPACE_VAR_TEST_OVERRIDE"
+	DefaultVarRegistry.Register(&fillerFunc{
+		name:         name,
+		errorMessage: "Current context is not a space, but predefined var is used",
+		fill: func(kubeContext string) (*string, error) {
+			// simulates running outside a DevSpace Cloud space
+			return nil, nil
+		},
+	})
+	t.Cleanup(func() { DefaultVarRegistry.unregister(name) })
+
+	os.Setenv(envOverridePrefix+name, "forced-value")
+	defer os.Unsetenv(envOverridePrefix + name)
+
+	err := fillPredefinedVars("")
+	assert.NilError(t, err)
+
+	found, value, err := getPredefinedVar(name, "")
+	assert.NilError(t, err)
+	assert.Equal(t, found, true)
+	assert.Equal(t, value, "forced-value")
+}
+
+// TestGitVarsAreUnsetOutsideRepo covers the shallow-clone / no-.git scenario:
+// running the real git-backed Fillers from a directory that isn't a git
+// repository at all must report "unset" (nil, nil), not fail hard or shell
+// out successfully with garbage output.
+func TestGitVarsAreUnsetOutsideRepo(t *testing.T) {
+	dir := t.TempDir()
+
+	wd, err := os.Getwd()
+	assert.NilError(t, err)
+	defer os.Chdir(wd)
+	assert.NilError(t, os.Chdir(dir))
+
+	for _, filler := range []Filler{gitBranchVar{}, gitTagVar{}, gitCommitVar{}, gitCommitFullVar{}, gitRemoteVar{}, gitDirtyVar{}, gitDescribeVar{}} {
+		val, err := filler.Fill("")
+		assert.NilError(t, err)
+		assert.Assert(t, val == nil, "%s should be unset outside a git repository", filler.Name())
+	}
+}
+
+// TestNamespaceVarFallsBackToDefaultWithoutKubeconfig covers the
+// no-kubeconfig scenario: pointing KUBECONFIG at a file that doesn't exist
+// must fall back to the "default" namespace instead of failing hard.
+func TestNamespaceVarFallsBackToDefaultWithoutKubeconfig(t *testing.T) {
+	dir := t.TempDir()
+
+	os.Setenv("KUBECONFIG", filepath.Join(dir, "does-not-exist"))
+	defer os.Unsetenv("KUBECONFIG")
+
+	val, err := (namespaceVar{}).Fill("")
+	assert.NilError(t, err)
+	assert.Assert(t, val != nil)
+	assert.Equal(t, *val, "default")
+}
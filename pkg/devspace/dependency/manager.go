@@ -0,0 +1,215 @@
+package dependency
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/git"
+	"github.com/loft-sh/devspace/pkg/util/log"
+
+	"github.com/pkg/errors"
+)
+
+// dependencyFolder is where dependency git repositories are checked out, relative to the project root
+const dependencyFolder = ".devspace/dependencies"
+
+// dependencyLocalPath returns the local checkout path for a dependency
+func dependencyLocalPath(dep *latest.DependencyConfig) string {
+	return filepath.Join(dependencyFolder, dep.Name)
+}
+
+// Manager can update, deploy and purge the dependencies configured in devspace.yaml
+type Manager interface {
+	// UpdateAll updates every dependency configured in devspace.yaml
+	UpdateAll() error
+
+	// UpdateSelected updates only the dependencies matching names (by name
+	// or source path/repo) and returns how many were matched and updated.
+	// An empty names selects every dependency. It errors if any requested
+	// name doesn't match a configured dependency
+	UpdateSelected(names []string, opts UpdateOptions) (int, error)
+}
+
+// UpdateOptions controls how UpdateSelected updates the selected dependencies
+type UpdateOptions struct {
+	// Branch overrides the ref that is checked out for the selected
+	// dependencies instead of the one configured in devspace.yaml
+	Branch string
+
+	// Parallel bounds how many dependencies are updated concurrently. Values <= 1 update sequentially
+	Parallel int
+
+	// SkipPush is a no-op, kept for flag parity with other `devspace update`/`devspace deploy` commands
+	SkipPush bool
+
+	// DryRun prints what would be updated instead of touching any git repository
+	DryRun bool
+}
+
+type manager struct {
+	config        *latest.Config
+	client        interface{}
+	configOptions *loader.ConfigOptions
+	log           log.Logger
+}
+
+// NewManager creates a new dependency manager for the given config. Callers
+// that want a dependency's local checkout path available as a predefined var
+// (DEVSPACE_DEPENDENCY_<NAME>_PATH) for the parent devspace.yaml's own
+// ${VAR} substitution must call RegisterVars before loading that config --
+// by the time a *latest.Config reaches NewManager, substitution has already
+// happened.
+func NewManager(config *latest.Config, client interface{}, configOptions *loader.ConfigOptions, log log.Logger) Manager {
+	return &manager{
+		config:        config,
+		client:        client,
+		configOptions: configOptions,
+		log:           log,
+	}
+}
+
+// UpdateAll updates the git repositories of every dependency configured in devspace.yaml
+func (m *manager) UpdateAll() error {
+	_, err := m.UpdateSelected(nil, UpdateOptions{})
+	return err
+}
+
+// updateResult is the outcome of updating a single dependency
+type updateResult struct {
+	name string
+	err  error
+}
+
+// UpdateSelected updates the git repositories of the dependencies matching
+// names (or all of them, if names is empty) using a worker pool bounded by
+// opts.Parallel. Per-dependency errors are aggregated and reported together
+// at the end instead of aborting on the first failure, so one broken
+// dependency doesn't block updating the rest.
+func (m *manager) UpdateSelected(names []string, opts UpdateOptions) (int, error) {
+	deps, err := m.selectDependencies(names)
+	if err != nil {
+		return 0, err
+	}
+	if len(deps) == 0 {
+		m.log.Info("No dependencies to update")
+		return 0, nil
+	}
+
+	parallel := opts.Parallel
+	if parallel <= 0 {
+		parallel = 1
+	}
+
+	results := make(chan updateResult, len(deps))
+	semaphore := make(chan struct{}, parallel)
+	wg := sync.WaitGroup{}
+
+	for _, dep := range deps {
+		dep := dep
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			if opts.DryRun {
+				m.log.Infof("Would update dependency %s", dep.Name)
+				results <- updateResult{name: dep.Name}
+				return
+			}
+
+			m.log.Infof("Updating dependency %s", dep.Name)
+			results <- updateResult{name: dep.Name, err: m.updateDependency(dep, opts)}
+		}()
+	}
+
+	wg.Wait()
+	close(results)
+
+	failed := []string{}
+	for result := range results {
+		if result.err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", result.name, result.err))
+			continue
+		}
+
+		if !opts.DryRun {
+			m.log.Donef("Successfully updated dependency %s", result.name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return len(deps) - len(failed), errors.Errorf("failed to update %d/%d dependencies:\n- %s", len(failed), len(deps), strings.Join(failed, "\n- "))
+	}
+
+	return len(deps), nil
+}
+
+// selectDependencies returns the configured dependencies matching names (by
+// name or source path/git repo). An empty names returns every dependency. It
+// errors if any requested name doesn't match a configured dependency, so a
+// typo'd --dependency name can't silently be dropped from the selection
+func (m *manager) selectDependencies(names []string) ([]*latest.DependencyConfig, error) {
+	if len(names) == 0 {
+		return m.config.Dependencies, nil
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	matched := map[string]bool{}
+	selected := []*latest.DependencyConfig{}
+	for _, dep := range m.config.Dependencies {
+		keys := []string{dep.Name}
+		if dep.Source != nil {
+			keys = append(keys, dep.Source.Path, dep.Source.Git)
+		}
+
+		isSelected := false
+		for _, key := range keys {
+			if wanted[key] {
+				matched[key] = true
+				isSelected = true
+			}
+		}
+		if isSelected {
+			selected = append(selected, dep)
+		}
+	}
+
+	unknown := []string{}
+	for _, name := range names {
+		if !matched[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, errors.Errorf("unknown dependency: %s", strings.Join(unknown, ", "))
+	}
+
+	return selected, nil
+}
+
+// updateDependency fetches and checks out the latest revision of a single dependency
+func (m *manager) updateDependency(dep *latest.DependencyConfig, opts UpdateOptions) error {
+	if dep.Source == nil || dep.Source.Git == "" {
+		// local path dependencies have nothing to update
+		return nil
+	}
+
+	branch := dep.Source.Branch
+	if opts.Branch != "" {
+		branch = opts.Branch
+	}
+
+	gitRepo := git.NewGitRepository(dependencyLocalPath(dep), dep.Source.Git)
+	return gitRepo.Update(branch)
+}
@@ -0,0 +1,82 @@
+package dependency
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/configutil"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// defaultConfigPath is used when configPath isn't set, matching the config loader's own default
+const defaultConfigPath = "devspace.yaml"
+
+// rawDependenciesConfig captures just enough of devspace.yaml's shape to read
+// out the configured dependency names without going through the full config
+// loader, which would already have resolved every ${VAR} by the time it returns
+type rawDependenciesConfig struct {
+	Dependencies []struct {
+		Name string `yaml:"name"`
+	} `yaml:"dependencies"`
+}
+
+// RegisterVars does a lightweight pre-scan of configPath's `dependencies:`
+// list and registers each dependency's local checkout path as a predefined
+// var (DEVSPACE_DEPENDENCY_<NAME>_PATH). It must be called before the
+// devspace.yaml is loaded: Load() resolves every ${VAR} at parse time, so
+// registering these vars against an already-loaded config is too late for
+// the parent devspace.yaml to reference them.
+func RegisterVars(configPath string) error {
+	if configPath == "" {
+		configPath = defaultConfigPath
+	}
+
+	raw, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return errors.Wrap(err, "read "+configPath)
+	}
+
+	config := &rawDependenciesConfig{}
+	if err := yaml.Unmarshal(raw, config); err != nil {
+		// a malformed devspace.yaml is reported with much better context by
+		// the full config loader; there is nothing to register here
+		return nil
+	}
+
+	for _, dep := range config.Dependencies {
+		if dep.Name == "" {
+			continue
+		}
+
+		configutil.RegisterVar(&dependencyPathVar{name: dep.Name, path: filepath.Join(dependencyFolder, dep.Name)})
+	}
+
+	return nil
+}
+
+// dependencyPathVar exposes the local checkout path of a configured
+// dependency as a predefined var
+type dependencyPathVar struct {
+	name string
+	path string
+}
+
+func (v *dependencyPathVar) Name() string {
+	return "DEVSPACE_DEPENDENCY_" + strings.ToUpper(v.name) + "_PATH"
+}
+func (v *dependencyPathVar) ErrorMessage() string {
+	return fmt.Sprintf("Dependency %s is not loaded, but predefined var %s is used", v.name, v.Name())
+}
+func (v *dependencyPathVar) Fill(kubeContext string) (*string, error) {
+	path := v.path
+	return &path, nil
+}
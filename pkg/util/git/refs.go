@@ -0,0 +1,143 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// checkIsRepo returns an error if gr.LocalPath is not inside a git work tree
+func (gr *GitRepository) checkIsRepo() error {
+	if _, err := exec.Command("git", "-C", gr.LocalPath, "rev-parse", "--is-inside-work-tree").CombinedOutput(); err != nil {
+		return errors.New("not a git repository")
+	}
+
+	return nil
+}
+
+// GetBranch returns the name of the currently checked out branch, or "HEAD"
+// if the repository is in detached HEAD state
+func (gr *GitRepository) GetBranch() (string, error) {
+	if err := gr.checkIsRepo(); err != nil {
+		return "", err
+	}
+
+	out, err := exec.Command("git", "-C", gr.LocalPath, "rev-parse", "--abbrev-ref", "HEAD").CombinedOutput()
+	if err != nil {
+		return "", errors.Errorf("get branch: %v\n%s", err, string(out))
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GetTag returns the nearest annotated tag reachable from HEAD, or an empty
+// string if there is none
+func (gr *GitRepository) GetTag() (string, error) {
+	if err := gr.checkIsRepo(); err != nil {
+		return "", err
+	}
+
+	out, err := exec.Command("git", "-C", gr.LocalPath, "describe", "--abbrev=0").CombinedOutput()
+	if err != nil {
+		// no annotated tag reachable from HEAD, not an error
+		return "", nil
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GetRemote returns the URL of the "origin" remote, or of the first
+// configured remote if "origin" doesn't exist
+func (gr *GitRepository) GetRemote() (string, error) {
+	if err := gr.checkIsRepo(); err != nil {
+		return "", err
+	}
+
+	out, err := exec.Command("git", "-C", gr.LocalPath, "remote", "get-url", "origin").CombinedOutput()
+	if err == nil {
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	remotes, err := exec.Command("git", "-C", gr.LocalPath, "remote").CombinedOutput()
+	if err != nil {
+		return "", nil
+	}
+
+	remoteNames := strings.Fields(string(remotes))
+	if len(remoteNames) == 0 {
+		// no remote configured, not an error
+		return "", nil
+	}
+
+	out, err = exec.Command("git", "-C", gr.LocalPath, "remote", "get-url", remoteNames[0]).CombinedOutput()
+	if err != nil {
+		return "", nil
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// IsDirty returns true if the working tree has uncommitted changes
+func (gr *GitRepository) IsDirty() (bool, error) {
+	if err := gr.checkIsRepo(); err != nil {
+		return false, err
+	}
+
+	out, err := exec.Command("git", "-C", gr.LocalPath, "status", "--porcelain").CombinedOutput()
+	if err != nil {
+		return false, err
+	}
+
+	return strings.TrimSpace(string(out)) != "", nil
+}
+
+// Update fetches the latest changes from the remote and checks out branch,
+// cloning the repository first if it doesn't exist locally yet. If branch is
+// empty, a fresh clone gets the remote's default branch and an existing
+// checkout stays on its currently checked out branch
+func (gr *GitRepository) Update(branch string) error {
+	if _, err := exec.Command("git", "-C", gr.LocalPath, "rev-parse", "--is-inside-work-tree").CombinedOutput(); err != nil {
+		cloneArgs := []string{"clone", gr.RemoteURL, gr.LocalPath}
+		if branch != "" {
+			cloneArgs = append(cloneArgs, "--branch", branch)
+		}
+
+		if out, err := exec.Command("git", cloneArgs...).CombinedOutput(); err != nil {
+			return errors.Errorf("clone %s: %v\n%s", gr.RemoteURL, err, string(out))
+		}
+
+		return nil
+	}
+
+	if out, err := exec.Command("git", "-C", gr.LocalPath, "fetch", "origin").CombinedOutput(); err != nil {
+		return errors.Errorf("fetch: %v\n%s", err, string(out))
+	}
+
+	if branch != "" {
+		if out, err := exec.Command("git", "-C", gr.LocalPath, "checkout", branch).CombinedOutput(); err != nil {
+			return errors.Errorf("checkout %s: %v\n%s", branch, err, string(out))
+		}
+	}
+
+	pullArgs := []string{"-C", gr.LocalPath, "pull", "origin"}
+	if branch != "" {
+		pullArgs = append(pullArgs, branch)
+	}
+
+	if out, err := exec.Command("git", pullArgs...).CombinedOutput(); err != nil {
+		return errors.Errorf("pull: %v\n%s", err, string(out))
+	}
+
+	return nil
+}
+
+// Describe returns the output of `git describe --tags --always --dirty`
+func (gr *GitRepository) Describe() (string, error) {
+	out, err := exec.Command("git", "-C", gr.LocalPath, "describe", "--tags", "--always", "--dirty").CombinedOutput()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
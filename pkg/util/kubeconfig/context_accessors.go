@@ -0,0 +1,78 @@
+package kubeconfig
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// loadRawConfig loads the raw kubeconfig using the default client-go loading
+// rules, which respects $KUBECONFIG and the usual merge precedence
+func loadRawConfig() (*api.Config, error) {
+	rawConfig, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
+	if err != nil {
+		return nil, errors.Wrap(err, "load kube config")
+	}
+
+	return rawConfig, nil
+}
+
+// resolveContextName returns overrideKubeContext if set, otherwise the
+// kubeconfig's current-context
+func resolveContextName(config *api.Config, overrideKubeContext string) string {
+	if overrideKubeContext != "" {
+		return overrideKubeContext
+	}
+
+	return config.CurrentContext
+}
+
+// GetCurrentNamespace returns the namespace of the given (or current) kube
+// context, falling back to "default" if the context doesn't specify one
+func GetCurrentNamespace(overrideKubeContext string) (string, error) {
+	config, err := loadRawConfig()
+	if err != nil {
+		return "", err
+	}
+
+	context, ok := config.Contexts[resolveContextName(config, overrideKubeContext)]
+	if !ok || context.Namespace == "" {
+		return "default", nil
+	}
+
+	return context.Namespace, nil
+}
+
+// GetCurrentClusterName returns the name of the cluster the given (or
+// current) kube context points to
+func GetCurrentClusterName(overrideKubeContext string) (string, error) {
+	config, err := loadRawConfig()
+	if err != nil {
+		return "", err
+	}
+
+	contextName := resolveContextName(config, overrideKubeContext)
+	context, ok := config.Contexts[contextName]
+	if !ok {
+		return "", errors.Errorf("kube context `%s` not found", contextName)
+	}
+
+	return context.Cluster, nil
+}
+
+// GetCurrentAuthInfoName returns the name of the auth-info (user) the given
+// (or current) kube context uses
+func GetCurrentAuthInfoName(overrideKubeContext string) (string, error) {
+	config, err := loadRawConfig()
+	if err != nil {
+		return "", err
+	}
+
+	contextName := resolveContextName(config, overrideKubeContext)
+	context, ok := config.Contexts[contextName]
+	if !ok {
+		return "", errors.Errorf("kube context `%s` not found", contextName)
+	}
+
+	return context.AuthInfo, nil
+}